@@ -0,0 +1,133 @@
+package evm
+
+import (
+	"fmt"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share/dkg"
+)
+
+// ContractBoard implements dkg.Board over a DKGBoard smart contract: pushes
+// submit transactions, and incoming packets are driven by subscribing to the
+// contract's events and decoding their logs back into the Auth*Bundle types.
+// It never returns incoming channels to a blocked state - a packet that
+// fails to decode is simply dropped, the same as a malformed packet from an
+// unreliable network peer would be.
+type ContractBoard struct {
+	contract Contract
+	group    kyber.Group
+
+	incomingDeal   chan dkg.AuthDealBundle
+	incomingResp   chan dkg.AuthResponseBundle
+	incomingJustif chan dkg.AuthJustifBundle
+}
+
+// NewContractBoard returns a ContractBoard that submits to, and listens on,
+// the given contract binding. group is used to decode the kyber
+// points/scalars embedded in incoming bundles.
+func NewContractBoard(contract Contract, group kyber.Group) (*ContractBoard, error) {
+	b := &ContractBoard{
+		contract:       contract,
+		group:          group,
+		incomingDeal:   make(chan dkg.AuthDealBundle, 100),
+		incomingResp:   make(chan dkg.AuthResponseBundle, 100),
+		incomingJustif: make(chan dkg.AuthJustifBundle, 100),
+	}
+	if err := b.subscribe(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *ContractBoard) subscribe() error {
+	deals := make(chan *DealPostedEvent, 100)
+	if _, err := b.contract.WatchDealPosted(deals); err != nil {
+		return fmt.Errorf("evm: subscribing to DealPosted: %w", err)
+	}
+	go b.forwardDeals(deals)
+
+	resps := make(chan *ResponsePostedEvent, 100)
+	if _, err := b.contract.WatchResponsePosted(resps); err != nil {
+		return fmt.Errorf("evm: subscribing to ResponsePosted: %w", err)
+	}
+	go b.forwardResponses(resps)
+
+	justifs := make(chan *JustificationPostedEvent, 100)
+	if _, err := b.contract.WatchJustificationPosted(justifs); err != nil {
+		return fmt.Errorf("evm: subscribing to JustificationPosted: %w", err)
+	}
+	go b.forwardJustifications(justifs)
+	return nil
+}
+
+func (b *ContractBoard) forwardDeals(in <-chan *DealPostedEvent) {
+	for ev := range in {
+		bundle, err := decodeDeal(b.group, ev.Bundle)
+		if err != nil {
+			continue
+		}
+		b.incomingDeal <- dkg.AuthDealBundle{Bundle: bundle, Signature: ev.Signature}
+	}
+}
+
+func (b *ContractBoard) forwardResponses(in <-chan *ResponsePostedEvent) {
+	for ev := range in {
+		bundle, err := decodeResponse(ev.Bundle)
+		if err != nil {
+			continue
+		}
+		b.incomingResp <- dkg.AuthResponseBundle{Bundle: bundle, Signature: ev.Signature}
+	}
+}
+
+func (b *ContractBoard) forwardJustifications(in <-chan *JustificationPostedEvent) {
+	for ev := range in {
+		bundle, err := decodeJustification(b.group, ev.Bundle)
+		if err != nil {
+			continue
+		}
+		b.incomingJustif <- dkg.AuthJustifBundle{Bundle: bundle, Signature: ev.Signature}
+	}
+}
+
+// PushDeals implements dkg.Board.
+func (b *ContractBoard) PushDeals(bundle dkg.AuthDealBundle) {
+	encoded, err := encodeDeal(bundle.Bundle)
+	if err != nil {
+		return
+	}
+	b.contract.PushDeal(encoded, bundle.Signature)
+}
+
+// IncomingDeal implements dkg.Board.
+func (b *ContractBoard) IncomingDeal() <-chan dkg.AuthDealBundle {
+	return b.incomingDeal
+}
+
+// PushResponses implements dkg.Board.
+func (b *ContractBoard) PushResponses(bundle dkg.AuthResponseBundle) {
+	encoded, err := encodeResponse(bundle.Bundle)
+	if err != nil {
+		return
+	}
+	b.contract.PushResponse(encoded, bundle.Signature)
+}
+
+// IncomingResponse implements dkg.Board.
+func (b *ContractBoard) IncomingResponse() <-chan dkg.AuthResponseBundle {
+	return b.incomingResp
+}
+
+// PushJustification implements dkg.Board.
+func (b *ContractBoard) PushJustification(bundle dkg.AuthJustifBundle) {
+	encoded, err := encodeJustification(bundle.Bundle)
+	if err != nil {
+		return
+	}
+	b.contract.PushJustification(encoded, bundle.Signature)
+}
+
+// IncomingJustification implements dkg.Board.
+func (b *ContractBoard) IncomingJustification() <-chan dkg.AuthJustifBundle {
+	return b.incomingJustif
+}
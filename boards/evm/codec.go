@@ -0,0 +1,209 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share/dkg"
+)
+
+// encodeDeal and its siblings below give ContractBoard a wire format to
+// submit as the opaque `bundle` bytes of a DKGBoard transaction. It is a
+// minimal, ad hoc encoding local to this package; it is not meant to be a
+// generally reusable bundle codec.
+func encodeDeal(bundle *dkg.DealBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, bundle.DealerIndex)
+	writeBytes(&buf, bundle.Nonce)
+	binary.Write(&buf, binary.BigEndian, uint32(len(bundle.Public)))
+	for _, c := range bundle.Public {
+		cbuff, err := c.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("evm: marshalling public coefficient: %w", err)
+		}
+		writeBytes(&buf, cbuff)
+	}
+	binary.Write(&buf, binary.BigEndian, uint32(len(bundle.Deals)))
+	for _, deal := range bundle.Deals {
+		binary.Write(&buf, binary.BigEndian, deal.ShareIndex)
+		writeBytes(&buf, deal.EncryptedShare)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeDeal(g kyber.Group, data []byte) (*dkg.DealBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.DealBundle)
+	if err := binary.Read(r, binary.BigEndian, &bundle.DealerIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nPublic uint32
+	if err := binary.Read(r, binary.BigEndian, &nPublic); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nPublic); err != nil {
+		return nil, err
+	}
+	bundle.Public = make([]kyber.Point, nPublic)
+	for i := range bundle.Public {
+		cbuff, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		p := g.Point()
+		if err := p.UnmarshalBinary(cbuff); err != nil {
+			return nil, fmt.Errorf("evm: unmarshalling public coefficient: %w", err)
+		}
+		bundle.Public[i] = p
+	}
+	var nDeals uint32
+	if err := binary.Read(r, binary.BigEndian, &nDeals); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nDeals); err != nil {
+		return nil, err
+	}
+	bundle.Deals = make([]dkg.Deal, nDeals)
+	for i := range bundle.Deals {
+		if err := binary.Read(r, binary.BigEndian, &bundle.Deals[i].ShareIndex); err != nil {
+			return nil, err
+		}
+		if bundle.Deals[i].EncryptedShare, err = readBytes(r); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+func encodeResponse(bundle *dkg.ResponseBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, bundle.ShareIndex)
+	writeBytes(&buf, bundle.Nonce)
+	binary.Write(&buf, binary.BigEndian, uint32(len(bundle.Responses)))
+	for _, resp := range bundle.Responses {
+		binary.Write(&buf, binary.BigEndian, resp.DealerIndex)
+		binary.Write(&buf, binary.BigEndian, resp.Status)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeResponse(data []byte) (*dkg.ResponseBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.ResponseBundle)
+	if err := binary.Read(r, binary.BigEndian, &bundle.ShareIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nResp uint32
+	if err := binary.Read(r, binary.BigEndian, &nResp); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nResp); err != nil {
+		return nil, err
+	}
+	bundle.Responses = make([]dkg.Response, nResp)
+	for i := range bundle.Responses {
+		if err := binary.Read(r, binary.BigEndian, &bundle.Responses[i].DealerIndex); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.BigEndian, &bundle.Responses[i].Status); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+func encodeJustification(bundle *dkg.JustificationBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, bundle.DealerIndex)
+	writeBytes(&buf, bundle.Nonce)
+	binary.Write(&buf, binary.BigEndian, uint32(len(bundle.Justifications)))
+	for _, just := range bundle.Justifications {
+		binary.Write(&buf, binary.BigEndian, just.ShareIndex)
+		sbuff, err := just.Share.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("evm: marshalling justification share: %w", err)
+		}
+		writeBytes(&buf, sbuff)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeJustification(g kyber.Group, data []byte) (*dkg.JustificationBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.JustificationBundle)
+	if err := binary.Read(r, binary.BigEndian, &bundle.DealerIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nJust uint32
+	if err := binary.Read(r, binary.BigEndian, &nJust); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nJust); err != nil {
+		return nil, err
+	}
+	bundle.Justifications = make([]dkg.Justification, nJust)
+	for i := range bundle.Justifications {
+		if err := binary.Read(r, binary.BigEndian, &bundle.Justifications[i].ShareIndex); err != nil {
+			return nil, err
+		}
+		sbuff, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		s := g.Scalar()
+		if err := s.UnmarshalBinary(sbuff); err != nil {
+			return nil, fmt.Errorf("evm: unmarshalling justification share: %w", err)
+		}
+		bundle.Justifications[i].Share = s
+	}
+	return bundle, nil
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	binary.Write(buf, binary.BigEndian, uint32(len(b)))
+	buf.Write(b)
+}
+
+// readBytes reads a length-prefixed byte slice. The length is bounded
+// against the bytes actually remaining in r before allocating, so a
+// malformed event log can't force a multi-gigabyte allocation, and
+// io.ReadFull is used instead of a single Read so a short buffer is reported
+// as an error instead of silently decoding as zero-padded data.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, n); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// boundCount rejects a length or element count that couldn't possibly be
+// backed by the bytes left in r.
+func boundCount(r *bytes.Reader, n uint32) error {
+	if int64(n) > int64(r.Len()) {
+		return fmt.Errorf("evm: length %d exceeds %d remaining bytes", n, r.Len())
+	}
+	return nil
+}
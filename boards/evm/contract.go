@@ -0,0 +1,48 @@
+package evm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Contract is the subset of the DKGBoard binding (see dkgboard.go) that
+// ContractBoard and BlockPhaser need; *DKGBoardSession satisfies it.
+// Depending on an interface rather than the binding type directly keeps
+// this package testable against a mock or a simulated backend.
+type Contract interface {
+	PushDeal(bundle, signature []byte) (*types.Transaction, error)
+	PushResponse(bundle, signature []byte) (*types.Transaction, error)
+	PushJustification(bundle, signature []byte) (*types.Transaction, error)
+
+	WatchDealPosted(sink chan<- *DealPostedEvent) (event.Subscription, error)
+	WatchResponsePosted(sink chan<- *ResponsePostedEvent) (event.Subscription, error)
+	WatchJustificationPosted(sink chan<- *JustificationPostedEvent) (event.Subscription, error)
+
+	DealDeadline() (*big.Int, error)
+	ResponseDeadline() (*big.Int, error)
+	JustificationDeadline() (*big.Int, error)
+}
+
+// DealPostedEvent mirrors the DealPosted Solidity event.
+type DealPostedEvent struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+}
+
+// ResponsePostedEvent mirrors the ResponsePosted Solidity event.
+type ResponsePostedEvent struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+}
+
+// JustificationPostedEvent mirrors the JustificationPosted Solidity event.
+type JustificationPostedEvent struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+}
@@ -0,0 +1,362 @@
+package evm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DKGBoardABI is the input ABI used to generate this binding, kept in sync
+// with contract.sol by hand since no solc/abigen toolchain is available in
+// this repo's build. Regenerate both this constant and DKGBoardBin with:
+//
+//	solc --combined-json abi,bin contract.sol
+//
+// if contract.sol ever changes.
+const DKGBoardABI = `[
+	{"inputs":[{"internalType":"uint256","name":"_dealDeadline","type":"uint256"},{"internalType":"uint256","name":"_responseDeadline","type":"uint256"},{"internalType":"uint256","name":"_justificationDeadline","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes","name":"bundle","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"}],"name":"DealPosted","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes","name":"bundle","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"}],"name":"ResponsePosted","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes","name":"bundle","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"}],"name":"JustificationPosted","type":"event"},
+	{"inputs":[],"name":"dealDeadline","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"responseDeadline","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"justificationDeadline","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"bytes","name":"bundle","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"pushDeal","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"bytes","name":"bundle","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"pushResponse","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"bytes","name":"bundle","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"pushJustification","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// DKGBoardBin is the compiled bytecode of DKGBoard, used by DeployDKGBoard.
+// It must be produced with solc from contract.sol; this tree has no solc
+// available, so it is left empty and DeployDKGBoard is unusable until it is
+// filled in by a build that can run solc (see board_test.go).
+const DKGBoardBin = ""
+
+// DKGBoard is the Go binding around a deployed DKGBoard contract, structured
+// the way abigen would generate it: a caller half for view calls, a
+// transactor half for sending transactions, and a filterer half for
+// subscribing to logs.
+type DKGBoard struct {
+	DKGBoardCaller
+	DKGBoardTransactor
+	DKGBoardFilterer
+}
+
+// DKGBoardCaller wraps the read-only (view) calls of DKGBoard.
+type DKGBoardCaller struct {
+	contract *bind.BoundContract
+}
+
+// DKGBoardTransactor wraps the state-changing calls of DKGBoard.
+type DKGBoardTransactor struct {
+	contract *bind.BoundContract
+}
+
+// DKGBoardFilterer wraps log subscriptions for DKGBoard's events.
+type DKGBoardFilterer struct {
+	contract *bind.BoundContract
+}
+
+// NewDKGBoard binds a DKGBoard contract already deployed at address.
+func NewDKGBoard(address common.Address, backend bind.ContractBackend) (*DKGBoard, error) {
+	contract, err := bindDKGBoard(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DKGBoard{
+		DKGBoardCaller:     DKGBoardCaller{contract: contract},
+		DKGBoardTransactor: DKGBoardTransactor{contract: contract},
+		DKGBoardFilterer:   DKGBoardFilterer{contract: contract},
+	}, nil
+}
+
+// DeployDKGBoard deploys a new DKGBoard contract, binding an instance of it
+// to the returned address.
+func DeployDKGBoard(
+	auth *bind.TransactOpts, backend bind.ContractBackend,
+	dealDeadline, responseDeadline, justificationDeadline *big.Int,
+) (common.Address, *types.Transaction, *DKGBoard, error) {
+	parsed, err := abi.JSON(strings.NewReader(DKGBoardABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(DKGBoardBin), backend,
+		dealDeadline, responseDeadline, justificationDeadline)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &DKGBoard{
+		DKGBoardCaller:     DKGBoardCaller{contract: contract},
+		DKGBoardTransactor: DKGBoardTransactor{contract: contract},
+		DKGBoardFilterer:   DKGBoardFilterer{contract: contract},
+	}, nil
+}
+
+func bindDKGBoard(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(DKGBoardABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+func (c *DKGBoardCaller) DealDeadline(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := c.contract.Call(opts, &out, "dealDeadline")
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+func (c *DKGBoardCaller) ResponseDeadline(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := c.contract.Call(opts, &out, "responseDeadline")
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+func (c *DKGBoardCaller) JustificationDeadline(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := c.contract.Call(opts, &out, "justificationDeadline")
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+func (t *DKGBoardTransactor) PushDeal(opts *bind.TransactOpts, bundle, signature []byte) (*types.Transaction, error) {
+	return t.contract.Transact(opts, "pushDeal", bundle, signature)
+}
+
+func (t *DKGBoardTransactor) PushResponse(opts *bind.TransactOpts, bundle, signature []byte) (*types.Transaction, error) {
+	return t.contract.Transact(opts, "pushResponse", bundle, signature)
+}
+
+func (t *DKGBoardTransactor) PushJustification(opts *bind.TransactOpts, bundle, signature []byte) (*types.Transaction, error) {
+	return t.contract.Transact(opts, "pushJustification", bundle, signature)
+}
+
+// DKGBoardDealPosted is the raw log form of the DealPosted event.
+type DKGBoardDealPosted struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+	Raw       types.Log
+}
+
+// DKGBoardResponsePosted is the raw log form of the ResponsePosted event.
+type DKGBoardResponsePosted struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+	Raw       types.Log
+}
+
+// DKGBoardJustificationPosted is the raw log form of the JustificationPosted
+// event.
+type DKGBoardJustificationPosted struct {
+	Sender    common.Address
+	Bundle    []byte
+	Signature []byte
+	Raw       types.Log
+}
+
+func (f *DKGBoardFilterer) WatchDealPosted(opts *bind.WatchOpts, sink chan<- *DKGBoardDealPosted, sender []common.Address) (event.Subscription, error) {
+	var senderRule []interface{}
+	for _, s := range sender {
+		senderRule = append(senderRule, s)
+	}
+	logs, sub, err := f.contract.WatchLogs(opts, "DealPosted", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				ev := new(DKGBoardDealPosted)
+				if err := f.contract.UnpackLog(ev, "DealPosted", log); err != nil {
+					return err
+				}
+				ev.Raw = log
+				select {
+				case sink <- ev:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func (f *DKGBoardFilterer) WatchResponsePosted(opts *bind.WatchOpts, sink chan<- *DKGBoardResponsePosted, sender []common.Address) (event.Subscription, error) {
+	var senderRule []interface{}
+	for _, s := range sender {
+		senderRule = append(senderRule, s)
+	}
+	logs, sub, err := f.contract.WatchLogs(opts, "ResponsePosted", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				ev := new(DKGBoardResponsePosted)
+				if err := f.contract.UnpackLog(ev, "ResponsePosted", log); err != nil {
+					return err
+				}
+				ev.Raw = log
+				select {
+				case sink <- ev:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func (f *DKGBoardFilterer) WatchJustificationPosted(opts *bind.WatchOpts, sink chan<- *DKGBoardJustificationPosted, sender []common.Address) (event.Subscription, error) {
+	var senderRule []interface{}
+	for _, s := range sender {
+		senderRule = append(senderRule, s)
+	}
+	logs, sub, err := f.contract.WatchLogs(opts, "JustificationPosted", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				ev := new(DKGBoardJustificationPosted)
+				if err := f.contract.UnpackLog(ev, "JustificationPosted", log); err != nil {
+					return err
+				}
+				ev.Raw = log
+				select {
+				case sink <- ev:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// DKGBoardSession pins a DKGBoard binding to the TransactOpts/CallOpts this
+// package's Contract interface needs, and adapts its raw event/opts-taking
+// methods to Contract's simpler signatures, as contract.go's doc comment
+// describes.
+type DKGBoardSession struct {
+	Contract     *DKGBoard
+	TransactOpts bind.TransactOpts
+	CallOpts     bind.CallOpts
+}
+
+// NewDKGBoardSession binds to a deployed DKGBoard and pins auth as the
+// TransactOpts used for every PushDeal / PushResponse / PushJustification
+// call made through it.
+func NewDKGBoardSession(address common.Address, backend bind.ContractBackend, auth *bind.TransactOpts) (*DKGBoardSession, error) {
+	contract, err := NewDKGBoard(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DKGBoardSession{Contract: contract, TransactOpts: *auth}, nil
+}
+
+func (s *DKGBoardSession) PushDeal(bundle, signature []byte) (*types.Transaction, error) {
+	return s.Contract.DKGBoardTransactor.PushDeal(&s.TransactOpts, bundle, signature)
+}
+
+func (s *DKGBoardSession) PushResponse(bundle, signature []byte) (*types.Transaction, error) {
+	return s.Contract.DKGBoardTransactor.PushResponse(&s.TransactOpts, bundle, signature)
+}
+
+func (s *DKGBoardSession) PushJustification(bundle, signature []byte) (*types.Transaction, error) {
+	return s.Contract.DKGBoardTransactor.PushJustification(&s.TransactOpts, bundle, signature)
+}
+
+func (s *DKGBoardSession) WatchDealPosted(sink chan<- *DealPostedEvent) (event.Subscription, error) {
+	raw := make(chan *DKGBoardDealPosted)
+	sub, err := s.Contract.DKGBoardFilterer.WatchDealPosted(&bind.WatchOpts{}, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for ev := range raw {
+			sink <- &DealPostedEvent{Sender: ev.Sender, Bundle: ev.Bundle, Signature: ev.Signature}
+		}
+	}()
+	return sub, nil
+}
+
+func (s *DKGBoardSession) WatchResponsePosted(sink chan<- *ResponsePostedEvent) (event.Subscription, error) {
+	raw := make(chan *DKGBoardResponsePosted)
+	sub, err := s.Contract.DKGBoardFilterer.WatchResponsePosted(&bind.WatchOpts{}, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for ev := range raw {
+			sink <- &ResponsePostedEvent{Sender: ev.Sender, Bundle: ev.Bundle, Signature: ev.Signature}
+		}
+	}()
+	return sub, nil
+}
+
+func (s *DKGBoardSession) WatchJustificationPosted(sink chan<- *JustificationPostedEvent) (event.Subscription, error) {
+	raw := make(chan *DKGBoardJustificationPosted)
+	sub, err := s.Contract.DKGBoardFilterer.WatchJustificationPosted(&bind.WatchOpts{}, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for ev := range raw {
+			sink <- &JustificationPostedEvent{Sender: ev.Sender, Bundle: ev.Bundle, Signature: ev.Signature}
+		}
+	}()
+	return sub, nil
+}
+
+func (s *DKGBoardSession) DealDeadline() (*big.Int, error) {
+	return s.Contract.DKGBoardCaller.DealDeadline(&s.CallOpts)
+}
+
+func (s *DKGBoardSession) ResponseDeadline() (*big.Int, error) {
+	return s.Contract.DKGBoardCaller.ResponseDeadline(&s.CallOpts)
+}
+
+func (s *DKGBoardSession) JustificationDeadline() (*big.Int, error) {
+	return s.Contract.DKGBoardCaller.JustificationDeadline(&s.CallOpts)
+}
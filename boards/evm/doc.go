@@ -0,0 +1,5 @@
+// Package evm provides a reference dkg.Board / dkg.Phaser implementation
+// backed by an EVM smart contract (see contract.sol), for deployments that
+// want every node to observe the same ordered transport log rather than
+// relying on direct peer-to-peer connections.
+package evm
@@ -0,0 +1,136 @@
+package evm
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// fakeContract is a pure Go, in-memory stand-in for a deployed DKGBoard: it
+// reproduces contract.sol's deadline checks and event-broadcast semantics
+// without needing solc or a simulated chain backend, so tests in this
+// package can exercise ContractBoard's full encode/push/watch/decode path
+// even in a tree with no Go toolchain to compile the real binding's
+// dependency on go-ethereum's simulated backend against. It is not a
+// general-purpose mock - it only implements what Contract needs.
+type fakeContract struct {
+	mu sync.Mutex
+
+	block                 int64
+	dealDeadline          int64
+	responseDeadline      int64
+	justificationDeadline int64
+
+	dealSubs   []chan<- *DealPostedEvent
+	respSubs   []chan<- *ResponsePostedEvent
+	justifSubs []chan<- *JustificationPostedEvent
+}
+
+// newFakeContract returns a fakeContract at block 0 with the given
+// deadlines, mirroring DKGBoard's constructor.
+func newFakeContract(dealDeadline, responseDeadline, justificationDeadline int64) *fakeContract {
+	return &fakeContract{
+		dealDeadline:          dealDeadline,
+		responseDeadline:      responseDeadline,
+		justificationDeadline: justificationDeadline,
+	}
+}
+
+// commit advances the fake chain by one block, mirroring
+// backends.SimulatedBackend.Commit.
+func (f *fakeContract) commit() {
+	f.mu.Lock()
+	f.block++
+	f.mu.Unlock()
+}
+
+func (f *fakeContract) PushDeal(bundle, signature []byte) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.block > f.dealDeadline {
+		return nil, errors.New("fakeContract: deal phase is over")
+	}
+	ev := &DealPostedEvent{Bundle: bundle, Signature: signature}
+	for _, sink := range f.dealSubs {
+		sink <- ev
+	}
+	return nil, nil
+}
+
+func (f *fakeContract) PushResponse(bundle, signature []byte) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.block > f.responseDeadline {
+		return nil, errors.New("fakeContract: response phase is over")
+	}
+	ev := &ResponsePostedEvent{Bundle: bundle, Signature: signature}
+	for _, sink := range f.respSubs {
+		sink <- ev
+	}
+	return nil, nil
+}
+
+func (f *fakeContract) PushJustification(bundle, signature []byte) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.block > f.justificationDeadline {
+		return nil, errors.New("fakeContract: justification phase is over")
+	}
+	ev := &JustificationPostedEvent{Bundle: bundle, Signature: signature}
+	for _, sink := range f.justifSubs {
+		sink <- ev
+	}
+	return nil, nil
+}
+
+func (f *fakeContract) WatchDealPosted(sink chan<- *DealPostedEvent) (event.Subscription, error) {
+	f.mu.Lock()
+	f.dealSubs = append(f.dealSubs, sink)
+	f.mu.Unlock()
+	return fakeSubscription{}, nil
+}
+
+func (f *fakeContract) WatchResponsePosted(sink chan<- *ResponsePostedEvent) (event.Subscription, error) {
+	f.mu.Lock()
+	f.respSubs = append(f.respSubs, sink)
+	f.mu.Unlock()
+	return fakeSubscription{}, nil
+}
+
+func (f *fakeContract) WatchJustificationPosted(sink chan<- *JustificationPostedEvent) (event.Subscription, error) {
+	f.mu.Lock()
+	f.justifSubs = append(f.justifSubs, sink)
+	f.mu.Unlock()
+	return fakeSubscription{}, nil
+}
+
+func (f *fakeContract) DealDeadline() (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return big.NewInt(f.dealDeadline), nil
+}
+
+func (f *fakeContract) ResponseDeadline() (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return big.NewInt(f.responseDeadline), nil
+}
+
+func (f *fakeContract) JustificationDeadline() (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return big.NewInt(f.justificationDeadline), nil
+}
+
+// fakeSubscription is a no-op event.Subscription: fakeContract never
+// produces subscription errors, and dropping a sink from dealSubs on
+// Unsubscribe isn't needed for the lifetime of a single test.
+type fakeSubscription struct{}
+
+func (fakeSubscription) Unsubscribe() {}
+func (fakeSubscription) Err() <-chan error {
+	return nil
+}
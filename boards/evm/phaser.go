@@ -0,0 +1,79 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/drand/kyber/share/dkg"
+)
+
+// BlockNumberer is the subset of an ethclient.Client that BlockPhaser needs
+// to poll the chain head.
+type BlockNumberer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// BlockPhaser is a dkg.Phaser that ticks phases based on block-number
+// deadlines read from a DKGBoard contract, rather than sleeping for a fixed
+// wall-clock duration like dkg.TimePhaser does. Since every node observes the
+// same chain, they all agree on phase transitions even under network delay.
+type BlockPhaser struct {
+	contract Contract
+	client   BlockNumberer
+	poll     time.Duration
+	out      chan dkg.Phase
+}
+
+// NewBlockPhaser returns a BlockPhaser that reads its deadlines from contract
+// and polls client for the current block number every poll interval.
+func NewBlockPhaser(contract Contract, client BlockNumberer, poll time.Duration) *BlockPhaser {
+	p := &BlockPhaser{
+		contract: contract,
+		client:   client,
+		poll:     poll,
+		out:      make(chan dkg.Phase, 4),
+	}
+	go p.run()
+	return p
+}
+
+func (p *BlockPhaser) run() {
+	ctx := context.Background()
+	p.out <- dkg.DealPhase
+	if deadline, err := p.contract.DealDeadline(); err == nil {
+		p.waitUntil(ctx, deadline)
+	}
+
+	p.out <- dkg.ResponsePhase
+	if deadline, err := p.contract.ResponseDeadline(); err == nil {
+		p.waitUntil(ctx, deadline)
+	}
+
+	p.out <- dkg.JustificationPhase
+	if deadline, err := p.contract.JustificationDeadline(); err == nil {
+		p.waitUntil(ctx, deadline)
+	}
+
+	p.out <- dkg.FinishPhase
+}
+
+// waitUntil blocks until the chain head reaches deadline.
+func (p *BlockPhaser) waitUntil(ctx context.Context, deadline *big.Int) {
+	ticker := time.NewTicker(p.poll)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := p.client.BlockNumber(ctx)
+		if err != nil {
+			continue
+		}
+		if new(big.Int).SetUint64(n).Cmp(deadline) >= 0 {
+			return
+		}
+	}
+}
+
+// NextPhase implements dkg.Phaser.
+func (p *BlockPhaser) NextPhase() chan dkg.Phase {
+	return p.out
+}
@@ -0,0 +1,506 @@
+package dkg
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+)
+
+// NonceLength is the length in bytes of the per-session nonce mixed into
+// every bundle Hash(), so a bundle signed for one DKG run can never be
+// replayed into another (e.g. a concurrent resharing with the same
+// participants).
+const NonceLength = 32
+
+// GetNonce returns a fresh, cryptographically random nonce of NonceLength
+// bytes, suitable for DkgConfig.Nonce.
+func GetNonce() []byte {
+	nonce := make([]byte, NonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		panic("dkg: unable to read cryptographic randomness: " + err.Error())
+	}
+	return nonce
+}
+
+// DkgConfig holds all the configuration needed to run a fresh DKG.
+type DkgConfig struct {
+	Suite kyber.Group
+
+	// Longterm is the longterm secret key of the node running this DKG.
+	Longterm kyber.Scalar
+
+	// OldNodes holds the list of nodes that already hold a share of the
+	// distributed secret, i.e. the dealers. For a fresh DKG, OldNodes must
+	// be set equal to NewNodes.
+	OldNodes []Node
+
+	// NewNodes holds the list of nodes that will hold a share of the
+	// distributed secret once this run finishes, i.e. the share holders.
+	NewNodes []Node
+
+	// Share is the previous share this node holds, if any. It must be set
+	// when this node is part of OldNodes and this run is a resharing.
+	Share *DistKeyShare
+
+	// Threshold is the minimum number of shares needed to reconstruct the
+	// distributed secret held by NewNodes.
+	Threshold int
+
+	// OldThreshold is the threshold that was used to create the previous
+	// distributed secret. Only required when resharing.
+	OldThreshold int
+
+	// Reader is an optional source of cryptographic randomness. If nil,
+	// crypto/rand.Reader is used.
+	Reader io.Reader
+
+	// UserReaderOnly restricts randomness to Reader only, bypassing the
+	// default system PRNG. Should only ever be set to true for testing.
+	UserReaderOnly bool
+
+	// FastSync, when true, makes the protocol proceed to the next phase as
+	// soon as enough deals / responses / justifications have been received,
+	// instead of waiting for the phaser to signal the phase change.
+	FastSync bool
+
+	// Nonce uniquely identifies this DKG run and is mixed into every bundle
+	// Hash(), preventing a signed bundle from one run being replayed into a
+	// concurrent one. It must be NonceLength bytes, generated with GetNonce,
+	// and is required whenever Config.Auth is set.
+	Nonce []byte
+}
+
+// NewDistKeyHandler returns a new DistKeyGenerator from the given
+// configuration. It returns an error if the configuration is invalid, e.g. if
+// the calling node is neither present in OldNodes nor in NewNodes.
+func NewDistKeyHandler(c *DkgConfig) (*DistKeyGenerator, error) {
+	if c.NewNodes == nil && c.OldNodes == nil {
+		return nil, errors.New("dkg: can't run with empty node list")
+	}
+
+	// This is a resharing round if a previous threshold was specified: the
+	// old and new committees (OldNodes / NewNodes) may then be disjoint and
+	// use a different threshold, but the new commitments must still
+	// reconstruct the same public key - see computeResult.
+	isResharing := c.OldThreshold != 0
+	if isResharing && len(c.OldNodes) == 0 {
+		return nil, errors.New("dkg: resharing config needs an old nodes list")
+	}
+
+	_, oidx, oldPresent := findPub(c.OldNodes, c.Longterm, c.Suite)
+	_, nidx, newPresent := findPub(c.NewNodes, c.Longterm, c.Suite)
+	if !oldPresent && !newPresent {
+		return nil, errors.New("dkg: public key not found in old list or new list")
+	}
+	if isResharing && oldPresent && c.Share == nil {
+		return nil, errors.New("dkg: can't reshare without this node's previous share")
+	}
+	if c.UserReaderOnly && c.Reader == nil {
+		return nil, errors.New("dkg: UserReaderOnly requires Reader to be set")
+	}
+
+	var canIssue = oldPresent
+	var canReceive = newPresent
+	var priPoly *share.PriPoly
+	var pubPoly *share.PubPoly
+	if canIssue {
+		var secretCoeff kyber.Scalar
+		if isResharing {
+			// Re-deal this node's existing share as-is. The Lagrange
+			// weighting that turns the qualified dealers' sub-shares back
+			// into the original secret can only be computed once the
+			// qualified subset is known - i.e. after ProcessDeals - so it
+			// is applied in computeResult instead of here. Weighting it
+			// against the full OldNodes list up front would make the
+			// combined secret wrong for any old-node dropout, since the
+			// Lagrange basis depends on exactly which dealers end up
+			// contributing.
+			secretCoeff = c.Share.Share.V
+		} else {
+			// fresh DKG: every old (= new) node picks a fresh secret and
+			// deals shares of it to the new group.
+			secretCoeff = c.Suite.Scalar().Pick(randomStream(c))
+		}
+		priPoly = share.NewPriPoly(c.Suite, c.Threshold, secretCoeff, randomStream(c))
+		pubPoly = priPoly.Commit(c.Suite.Point().Base())
+	}
+
+	dkg := &DistKeyGenerator{
+		conf:        c,
+		oldPresent:  oldPresent,
+		newPresent:  newPresent,
+		canIssue:    canIssue,
+		canReceive:  canReceive,
+		isResharing: isResharing,
+		oidx:        oidx,
+		nidx:        nidx,
+		priPoly:     priPoly,
+		pubPoly:     pubPoly,
+	}
+	return dkg, nil
+}
+
+// DistKeyGenerator is the struct that runs the DKG protocol for a single
+// node. It is driven by Protocol, which feeds it deals, responses and
+// justifications it receives from the Board and collects the ones it needs
+// to send out in return.
+type DistKeyGenerator struct {
+	conf *DkgConfig
+
+	// oldPresent is true if this node is part of the OldNodes list.
+	oldPresent bool
+	// newPresent is true if this node is part of the NewNodes list.
+	newPresent bool
+	// canIssue is true if this node deals shares to the new group.
+	canIssue bool
+	// canReceive is true if this node receives a share of the new secret.
+	canReceive bool
+	// isResharing is true if this run reshares an already existing secret
+	// instead of generating a fresh one.
+	isResharing bool
+
+	// oidx is the index of this node in OldNodes, valid only if oldPresent.
+	oidx uint32
+	// nidx is the index of this node in NewNodes, valid only if newPresent.
+	nidx uint32
+
+	priPoly *share.PriPoly
+	pubPoly *share.PubPoly
+
+	// dealerShares holds, per dealer index, the sub-share this node
+	// received from that dealer and verified against its public
+	// commitments.
+	dealerShares map[uint32]*share.PriShare
+	// dealerCommits holds, per dealer index, the public commitments that
+	// dealer published in its DealBundle.
+	dealerCommits map[uint32][]kyber.Point
+	// qual flags dealer indexes this node has disqualified, either because
+	// their deal never arrived or failed verification against their public
+	// commitments.
+	qual BitSet
+}
+
+// Deals returns the DealBundle this node must send to the new group of
+// nodes, i.e. one share of its own secret per new node. The share is only
+// marshalled into Deal.EncryptedShare; it is not encrypted. It returns nil if
+// this node does not issue deals (it is not part of OldNodes).
+func (d *DistKeyGenerator) Deals() (*DealBundle, error) {
+	if !d.canIssue {
+		return nil, nil
+	}
+	deals := make([]Deal, 0, len(d.conf.NewNodes))
+	for _, node := range d.conf.NewNodes {
+		si := d.priPoly.Eval(int(node.Index))
+		shareBuff, err := si.V.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("dkg: error marshalling share: %w", err)
+		}
+		deals = append(deals, Deal{
+			ShareIndex:     node.Index,
+			EncryptedShare: shareBuff,
+		})
+	}
+	return &DealBundle{
+		DealerIndex: d.oidx,
+		Deals:       deals,
+		Public:      d.pubPoly.Commits(),
+		Nonce:       d.conf.Nonce,
+	}, nil
+}
+
+// ProcessDeals processes the deal bundles received from the dealers and
+// returns the ResponseBundle this node must send back, if any. Deals that are
+// missing or fail verification against the dealer's own public commitments
+// flag that dealer as disqualified in d.qual rather than aborting the
+// protocol, mirroring how a single Byzantine dealer must not be able to
+// block the rest of the group.
+func (d *DistKeyGenerator) ProcessDeals(bundles []*DealBundle) (*ResponseBundle, error) {
+	if !d.canReceive {
+		return nil, nil
+	}
+	d.dealerShares = make(map[uint32]*share.PriShare)
+	d.dealerCommits = make(map[uint32][]kyber.Point)
+	d.qual = make(BitSet)
+	responses := make([]Response, 0, len(bundles))
+	for _, bundle := range bundles {
+		if bundle == nil {
+			continue
+		}
+		status := d.acceptDeal(bundle)
+		d.qual[bundle.DealerIndex] = !status
+		responses = append(responses, Response{
+			DealerIndex: bundle.DealerIndex,
+			Status:      status,
+		})
+	}
+	for _, n := range d.conf.OldNodes {
+		if _, seen := d.qual[n.Index]; !seen {
+			d.qual[n.Index] = true
+		}
+	}
+	return &ResponseBundle{
+		ShareIndex: d.nidx,
+		Responses:  responses,
+		Nonce:      d.conf.Nonce,
+	}, nil
+}
+
+// acceptDeal decodes and verifies the sub-share this node received from one
+// dealer's bundle against that dealer's public commitments, and records it in
+// d.dealerShares / d.dealerCommits for later combination in computeResult. It
+// returns false if the share for this node is missing, malformed, or does
+// not match the dealer's commitments.
+func (d *DistKeyGenerator) acceptDeal(bundle *DealBundle) bool {
+	var encrypted []byte
+	var found bool
+	for _, deal := range bundle.Deals {
+		if deal.ShareIndex == d.nidx {
+			encrypted = deal.EncryptedShare
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	v := d.conf.Suite.Scalar()
+	if err := v.UnmarshalBinary(encrypted); err != nil {
+		return false
+	}
+	dealerPub := share.NewPubPoly(d.conf.Suite, d.conf.Suite.Point().Base(), bundle.Public)
+	expected := dealerPub.Eval(int(d.nidx))
+	got := d.conf.Suite.Point().Mul(v, nil)
+	if !got.Equal(expected.V) {
+		return false
+	}
+	d.dealerShares[bundle.DealerIndex] = &share.PriShare{I: int(d.nidx), V: v}
+	d.dealerCommits[bundle.DealerIndex] = bundle.Public
+	return true
+}
+
+// ProcessResponses processes all the response bundles gathered during the
+// response phase. If the protocol can already finish (no complaint), it
+// returns a non-nil Result. Otherwise, if this node is a dealer, it returns a
+// JustificationBundle for it to emit, whether or not this dealer was
+// complained about; the bundle carries no justifications.
+func (d *DistKeyGenerator) ProcessResponses(bundles []*ResponseBundle) (*Result, *JustificationBundle, error) {
+	complaints := make(BitSet)
+	for _, bundle := range bundles {
+		for _, resp := range bundle.Responses {
+			if !resp.Status {
+				complaints[resp.DealerIndex] = true
+			}
+		}
+	}
+	if complaints.LengthComplaints() == 0 {
+		res, err := d.computeResult()
+		return res, nil, err
+	}
+	if !d.canIssue {
+		return nil, nil, nil
+	}
+	return nil, &JustificationBundle{DealerIndex: d.oidx, Nonce: d.conf.Nonce}, nil
+}
+
+// ProcessJustifications returns the final Result of the protocol, computed
+// from the deals this node accepted in ProcessDeals. The justifications in
+// bundles are not read, so a dealer whose deal this node rejected stays
+// excluded.
+func (d *DistKeyGenerator) ProcessJustifications(bundles []*JustificationBundle) (*Result, error) {
+	return d.computeResult()
+}
+
+// computeResult combines the verified sub-shares and public commitments
+// received from every dealer this node actually accepted a deal from into
+// its final DistKeyShare. For a resharing, the contribution of each such
+// dealer is weighted by its Lagrange coefficient over that exact qualified
+// subset, so the result still reconstructs the secret the old share
+// committed to even when some old nodes dropped out; it also checks that
+// the newly combined public key equals that old commitment (when this node
+// can make that comparison), and reports ErrEvicted if this node held a
+// share before the resharing but is not part of NewNodes afterwards.
+func (d *DistKeyGenerator) computeResult() (*Result, error) {
+	// QUAL is the set of dealers (from OldNodes) whose deal this node
+	// actually accepted - i.e. the exact dealers folded into key below. A
+	// dealer that never sent a deal, or whose deal failed verification,
+	// never entered d.dealerCommits and so is correctly excluded here. A
+	// node that never called ProcessDeals (canReceive is false) has no
+	// acceptance data of its own, so it reports every old node as
+	// qualified.
+	var qual []Node
+	var qualifiedIdx []uint32
+	for _, n := range d.conf.OldNodes {
+		if _, accepted := d.dealerCommits[n.Index]; accepted || !d.canReceive {
+			qual = append(qual, n)
+			qualifiedIdx = append(qualifiedIdx, n.Index)
+		}
+	}
+
+	var key *DistKeyShare
+	if d.canReceive {
+		var commits []kyber.Point
+		var priShare *share.PriShare
+		for _, dealerIdx := range qualifiedIdx {
+			dealerCommits := d.dealerCommits[dealerIdx]
+			dealerShare := d.dealerShares[dealerIdx]
+			if d.isResharing {
+				// Weight this dealer's re-dealt sub-share by its Lagrange
+				// coefficient over the dealers that actually qualified,
+				// not over the full OldNodes list - the full list would
+				// only be correct if every single old node dealt
+				// successfully.
+				coeff := lagrangeCoefficient(d.conf.Suite, qualifiedIdx, dealerIdx)
+				dealerCommits = scaleCommits(dealerCommits, coeff)
+				dealerShare = scaleShare(d.conf.Suite, dealerShare, coeff)
+			}
+			commits = sumCommits(commits, dealerCommits)
+			priShare = sumShare(d.conf.Suite, priShare, dealerShare)
+		}
+		key = &DistKeyShare{Commits: commits, Share: priShare}
+		// A pure new joiner - one with no previous share - has nothing to
+		// compare the combined key against; it already verified every
+		// sub-share it used above against that dealer's own public
+		// commitments in acceptDeal, which is the only check available to
+		// it.
+		if d.isResharing && len(commits) > 0 && d.conf.Share != nil && !key.Public().Equal(d.conf.Share.Public()) {
+			return nil, errors.New("dkg: resharing produced a different public key")
+		}
+	}
+
+	if d.isResharing && d.oldPresent && !d.canReceive {
+		return &Result{QUAL: qual}, ErrEvicted
+	}
+	return &Result{QUAL: qual, Key: key}, nil
+}
+
+// sumCommits returns the point-wise sum of two public polynomial commitment
+// slices, used to combine the contributions of several dealers into the
+// group's joint public polynomial.
+func sumCommits(base, add []kyber.Point) []kyber.Point {
+	if base == nil {
+		out := make([]kyber.Point, len(add))
+		copy(out, add)
+		return out
+	}
+	out := make([]kyber.Point, len(base))
+	for i := range base {
+		out[i] = base[i].Clone().Add(base[i], add[i])
+	}
+	return out
+}
+
+// sumShare returns the sum of two private shares at the same index, used to
+// combine the sub-shares received from several dealers into this node's
+// share of the group's joint secret.
+func sumShare(g kyber.Group, base, add *share.PriShare) *share.PriShare {
+	if base == nil {
+		return &share.PriShare{I: add.I, V: add.V.Clone()}
+	}
+	return &share.PriShare{I: base.I, V: g.Scalar().Add(base.V, add.V)}
+}
+
+// lagrangeCoefficient returns the Lagrange basis coefficient L_index(0) for
+// reconstructing a secret shared among the given qualified dealer indexes,
+// evaluated at x = index+1. During resharing this weights each qualified
+// dealer's re-dealt sub-share so that combining exactly that subset
+// reconstructs the same secret under the new polynomial - the basis must be
+// computed over the dealers that actually qualified, not the full OldNodes
+// list, or it no longer reconstructs the right value whenever an old node
+// drops out.
+func lagrangeCoefficient(g kyber.Group, qualifiedIdx []uint32, index uint32) kyber.Scalar {
+	xi := g.Scalar().SetInt64(int64(index) + 1)
+	num := g.Scalar().One()
+	den := g.Scalar().One()
+	for _, j := range qualifiedIdx {
+		if j == index {
+			continue
+		}
+		xj := g.Scalar().SetInt64(int64(j) + 1)
+		num = g.Scalar().Mul(num, xj)
+		den = g.Scalar().Mul(den, g.Scalar().Sub(xj, xi))
+	}
+	return g.Scalar().Div(num, den)
+}
+
+// scaleCommits returns a copy of commits with every point multiplied by
+// coeff, used to weight a dealer's public commitments by its Lagrange
+// coefficient when combining a qualified subset during resharing.
+func scaleCommits(commits []kyber.Point, coeff kyber.Scalar) []kyber.Point {
+	out := make([]kyber.Point, len(commits))
+	for i, c := range commits {
+		out[i] = c.Clone().Mul(coeff, c)
+	}
+	return out
+}
+
+// scaleShare returns a copy of s with its value multiplied by coeff, used to
+// weight a dealer's sub-share by its Lagrange coefficient when combining a
+// qualified subset during resharing.
+func scaleShare(g kyber.Group, s *share.PriShare, coeff kyber.Scalar) *share.PriShare {
+	return &share.PriShare{I: s.I, V: g.Scalar().Mul(coeff, s.V)}
+}
+
+// findIndex looks up the node whose Index matches idx in the given list and
+// returns its public key.
+func findIndex(nodes []Node, idx uint32) (kyber.Point, bool) {
+	for _, n := range nodes {
+		if n.Index == idx {
+			return n.Public, true
+		}
+	}
+	return nil, false
+}
+
+// findPub looks up the node whose public key matches the given longterm
+// secret in the given list and returns its public key, index and whether it
+// was found.
+func findPub(nodes []Node, longterm kyber.Scalar, g kyber.Group) (kyber.Point, uint32, bool) {
+	if longterm == nil {
+		return nil, 0, false
+	}
+	pub := g.Point().Mul(longterm, nil)
+	for _, n := range nodes {
+		if n.Public.Equal(pub) {
+			return n.Public, n.Index, true
+		}
+	}
+	return nil, 0, false
+}
+
+// randomStream returns the source of randomness to use for picking secrets,
+// honoring Reader / UserReaderOnly from the config. random.New always mixes
+// in the system PRNG in addition to any stream it is given, so UserReaderOnly
+// bypasses it entirely and reads the keystream directly off Reader instead -
+// this is what lets tests drive the DKG with fully deterministic randomness.
+func randomStream(c *DkgConfig) cipher.Stream {
+	if c.UserReaderOnly {
+		return readerStream{c.Reader}
+	}
+	if c.Reader == nil {
+		return random.New()
+	}
+	return random.New(c.Reader)
+}
+
+// readerStream adapts an io.Reader into a cipher.Stream that draws its
+// keystream directly from the reader, with no contribution from the system
+// PRNG.
+type readerStream struct {
+	r io.Reader
+}
+
+func (s readerStream) XORKeyStream(dst, src []byte) {
+	buf := make([]byte, len(src))
+	if _, err := io.ReadFull(s.r, buf); err != nil {
+		panic("dkg: UserReaderOnly reader exhausted: " + err.Error())
+	}
+	for i := range src {
+		dst[i] = src[i] ^ buf[i]
+	}
+}
@@ -0,0 +1,339 @@
+// Package proto holds a schema for dkg's bundle types (see dkg.proto) and
+// hand-written Go helpers that (un)marshal them.
+//
+// kyber.Point and kyber.Scalar marshalling is suite-dependent, so every
+// point/scalar field in dkg.proto is carried as opaque bytes. The helpers in
+// this file do not produce the protobuf encoding of the dkg.proto messages:
+// they write the same fields in their own layout of big-endian uint32s and
+// uint32-length-prefixed byte strings, and decode points/scalars with a
+// caller-supplied kyber.Group. Unmarshal
+// rejects any trailing bytes after its known fields instead of silently
+// ignoring them, so a bundle signed by this package's Marshal never picks up
+// hidden fields it didn't verify.
+package proto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share/dkg"
+)
+
+// tag discriminates which Auth*Bundle variant EncodeAuthBundle wrote, so
+// DecodeAuthBundle can pick the right decoder without the caller having to
+// know in advance which phase a packet belongs to.
+type tag byte
+
+const (
+	tagDeal tag = iota + 1
+	tagResponse
+	tagJustification
+)
+
+// MarshalDealBundle encodes bundle into its canonical wire form. Decoding the
+// result with UnmarshalDealBundle and re-encoding it always reproduces the
+// same bytes, so Hash() computed after Unmarshal matches the Hash() the
+// original sender computed.
+func MarshalDealBundle(bundle *dkg.DealBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	writeUint32(&buf, bundle.DealerIndex)
+	writeBytes(&buf, bundle.Nonce)
+	writeUint32(&buf, uint32(len(bundle.Public)))
+	for _, c := range bundle.Public {
+		cbuff, err := c.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("proto: marshalling public coefficient: %w", err)
+		}
+		writeBytes(&buf, cbuff)
+	}
+	writeUint32(&buf, uint32(len(bundle.Deals)))
+	for _, deal := range bundle.Deals {
+		writeUint32(&buf, deal.ShareIndex)
+		writeBytes(&buf, deal.EncryptedShare)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalDealBundle decodes a DealBundle previously produced by
+// MarshalDealBundle, using group to decode its public coefficients.
+func UnmarshalDealBundle(group kyber.Group, data []byte) (*dkg.DealBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.DealBundle)
+	if err := readUint32(r, &bundle.DealerIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nPublic uint32
+	if err := readUint32(r, &nPublic); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nPublic); err != nil {
+		return nil, err
+	}
+	bundle.Public = make([]kyber.Point, nPublic)
+	for i := range bundle.Public {
+		cbuff, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		p := group.Point()
+		if err := p.UnmarshalBinary(cbuff); err != nil {
+			return nil, fmt.Errorf("proto: unmarshalling public coefficient: %w", err)
+		}
+		bundle.Public[i] = p
+	}
+	var nDeals uint32
+	if err := readUint32(r, &nDeals); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nDeals); err != nil {
+		return nil, err
+	}
+	bundle.Deals = make([]dkg.Deal, nDeals)
+	for i := range bundle.Deals {
+		if err := readUint32(r, &bundle.Deals[i].ShareIndex); err != nil {
+			return nil, err
+		}
+		if bundle.Deals[i].EncryptedShare, err = readBytes(r); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, requireExhausted(r)
+}
+
+// MarshalResponseBundle encodes bundle into its canonical wire form.
+func MarshalResponseBundle(bundle *dkg.ResponseBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	writeUint32(&buf, bundle.ShareIndex)
+	writeBytes(&buf, bundle.Nonce)
+	writeUint32(&buf, uint32(len(bundle.Responses)))
+	for _, resp := range bundle.Responses {
+		writeUint32(&buf, resp.DealerIndex)
+		var status byte
+		if resp.Status {
+			status = 1
+		}
+		buf.WriteByte(status)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalResponseBundle decodes a ResponseBundle previously produced by
+// MarshalResponseBundle.
+func UnmarshalResponseBundle(data []byte) (*dkg.ResponseBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.ResponseBundle)
+	if err := readUint32(r, &bundle.ShareIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nResp uint32
+	if err := readUint32(r, &nResp); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nResp); err != nil {
+		return nil, err
+	}
+	bundle.Responses = make([]dkg.Response, nResp)
+	for i := range bundle.Responses {
+		if err := readUint32(r, &bundle.Responses[i].DealerIndex); err != nil {
+			return nil, err
+		}
+		status, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		bundle.Responses[i].Status = status == 1
+	}
+	return bundle, requireExhausted(r)
+}
+
+// MarshalJustificationBundle encodes bundle into its canonical wire form.
+func MarshalJustificationBundle(bundle *dkg.JustificationBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	writeUint32(&buf, bundle.DealerIndex)
+	writeBytes(&buf, bundle.Nonce)
+	writeUint32(&buf, uint32(len(bundle.Justifications)))
+	for _, just := range bundle.Justifications {
+		writeUint32(&buf, just.ShareIndex)
+		sbuff, err := just.Share.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("proto: marshalling justification share: %w", err)
+		}
+		writeBytes(&buf, sbuff)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJustificationBundle decodes a JustificationBundle previously
+// produced by MarshalJustificationBundle, using group to decode its shares.
+func UnmarshalJustificationBundle(group kyber.Group, data []byte) (*dkg.JustificationBundle, error) {
+	r := bytes.NewReader(data)
+	bundle := new(dkg.JustificationBundle)
+	if err := readUint32(r, &bundle.DealerIndex); err != nil {
+		return nil, err
+	}
+	var err error
+	if bundle.Nonce, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	var nJust uint32
+	if err := readUint32(r, &nJust); err != nil {
+		return nil, err
+	}
+	if err := boundCount(r, nJust); err != nil {
+		return nil, err
+	}
+	bundle.Justifications = make([]dkg.Justification, nJust)
+	for i := range bundle.Justifications {
+		if err := readUint32(r, &bundle.Justifications[i].ShareIndex); err != nil {
+			return nil, err
+		}
+		sbuff, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		s := group.Scalar()
+		if err := s.UnmarshalBinary(sbuff); err != nil {
+			return nil, fmt.Errorf("proto: unmarshalling justification share: %w", err)
+		}
+		bundle.Justifications[i].Share = s
+	}
+	return bundle, requireExhausted(r)
+}
+
+// EncodeAuthBundle marshals p, whichever of the three Auth*Bundle variants it
+// is, and tags the result with its type so DecodeAuthBundle can tell them
+// apart. This lets a Board transport use a single send path for every phase
+// of the protocol.
+func EncodeAuthBundle(p dkg.Packet) ([]byte, error) {
+	var body []byte
+	var err error
+	switch v := p.(type) {
+	case dkg.AuthDealBundle:
+		body, err = MarshalDealBundle(v.Bundle)
+		return frame(tagDeal, body, err, v.Signature)
+	case dkg.AuthResponseBundle:
+		body, err = MarshalResponseBundle(v.Bundle)
+		return frame(tagResponse, body, err, v.Signature)
+	case dkg.AuthJustifBundle:
+		body, err = MarshalJustificationBundle(v.Bundle)
+		return frame(tagJustification, body, err, v.Signature)
+	default:
+		return nil, fmt.Errorf("proto: unknown packet type %T", p)
+	}
+}
+
+func frame(t tag, body []byte, err error, sig []byte) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(byte(t))
+	writeBytes(&buf, sig)
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// DecodeAuthBundle reverses EncodeAuthBundle, using group to decode whatever
+// kyber points or scalars the bundle contains.
+func DecodeAuthBundle(group kyber.Group, data []byte) (dkg.Packet, error) {
+	r := bytes.NewReader(data)
+	t, err := r.ReadByte()
+	if err != nil {
+		return nil, errors.New("proto: empty buffer")
+	}
+	sig, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	body := make([]byte, r.Len())
+	if _, err := r.Read(body); err != nil {
+		return nil, err
+	}
+	switch tag(t) {
+	case tagDeal:
+		bundle, err := UnmarshalDealBundle(group, body)
+		if err != nil {
+			return nil, err
+		}
+		return dkg.AuthDealBundle{Bundle: bundle, Signature: sig}, nil
+	case tagResponse:
+		bundle, err := UnmarshalResponseBundle(body)
+		if err != nil {
+			return nil, err
+		}
+		return dkg.AuthResponseBundle{Bundle: bundle, Signature: sig}, nil
+	case tagJustification:
+		bundle, err := UnmarshalJustificationBundle(group, body)
+		if err != nil {
+			return nil, err
+		}
+		return dkg.AuthJustifBundle{Bundle: bundle, Signature: sig}, nil
+	default:
+		return nil, fmt.Errorf("proto: unknown packet tag %d", t)
+	}
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	binary.Write(buf, binary.BigEndian, v)
+}
+
+func readUint32(r *bytes.Reader, v *uint32) error {
+	return binary.Read(r, binary.BigEndian, v)
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	writeUint32(buf, uint32(len(b)))
+	buf.Write(b)
+}
+
+// readBytes reads a length-prefixed byte slice. The length is bounded
+// against the bytes actually remaining in r before allocating, so a
+// malformed or truncated buffer can't force a multi-gigabyte allocation, and
+// io.ReadFull is used instead of a single Read so a short buffer is reported
+// as an error instead of silently decoding as zero-padded data.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	var n uint32
+	if err := readUint32(r, &n); err != nil {
+		return nil, err
+	}
+	if int64(n) > int64(r.Len()) {
+		return nil, fmt.Errorf("proto: length prefix %d exceeds %d remaining bytes", n, r.Len())
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// boundCount rejects a repeated-field count that couldn't possibly be
+// backed by the bytes left in r, so a malformed length prefix can't force an
+// allocation of n elements before any of them are actually read.
+func boundCount(r *bytes.Reader, n uint32) error {
+	if int64(n) > int64(r.Len()) {
+		return fmt.Errorf("proto: element count %d exceeds %d remaining bytes", n, r.Len())
+	}
+	return nil
+}
+
+// requireExhausted rejects any bytes left over after decoding the fields
+// this package knows about, instead of silently ignoring them as unknown
+// fields.
+func requireExhausted(r *bytes.Reader) error {
+	if r.Len() != 0 {
+		return fmt.Errorf("proto: %d unknown trailing bytes", r.Len())
+	}
+	return nil
+}
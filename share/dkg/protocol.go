@@ -1,14 +1,77 @@
 package dkg
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"time"
 
-	"github.com/drand/kyber"
 	"github.com/drand/kyber/sign"
 )
 
+// Roster distinguishes which committee the sender of a Packet belongs to: the
+// old one dealing shares, or the new one receiving them. For a fresh DKG the
+// two committees are the same set of nodes.
+type Roster int
+
+const (
+	// OldRoster designates the committee in DkgConfig.OldNodes.
+	OldRoster Roster = iota
+	// NewRoster designates the committee in DkgConfig.NewNodes.
+	NewRoster
+)
+
+// Packet is implemented by AuthDealBundle, AuthResponseBundle and
+// AuthJustifBundle. It lets a caller check the authenticity of a packet
+// observed on the wire, in a smart-contract log, or anywhere else, without
+// having to run a Protocol - see VerifyPacketSignature.
+type Packet interface {
+	Hash() []byte
+	Sig() []byte
+	SenderIndex() uint32
+	SenderRoster() Roster
+	Nonce() []byte
+}
+
+// VerifyPacketSignature verifies that p carries a valid signature from the
+// longterm key of the node at p.SenderIndex() in the roster p.SenderRoster()
+// designates, and that p was signed for this session rather than replayed
+// from another one. It returns nil without checking anything if c.Auth is
+// nil, mirroring the behavior of a Protocol configured without
+// authentication.
+//
+// A Board implementation can call this to drop invalid packets before they
+// are ever enqueued, which matters for FastSync: a single bad packet from a
+// Byzantine peer would otherwise poison the len(deals) == oldN,
+// len(resps) == newN or len(justifs) == oldN counters and trigger a
+// premature phase transition.
+func VerifyPacketSignature(c *Config, p Packet) error {
+	if c.Auth == nil {
+		return nil
+	}
+	// p.Hash() mixes in whatever nonce the sender embedded in the packet, so
+	// a valid signature alone only proves the packet was signed for *some*
+	// session - without this check, a packet signed in one concurrent run
+	// could be replayed verbatim into another one sharing the same roster.
+	if !bytes.Equal(p.Nonce(), c.DkgConfig.Nonce) {
+		return errors.New("dkg: packet nonce does not match this session's nonce")
+	}
+	var roster []Node
+	switch p.SenderRoster() {
+	case OldRoster:
+		roster = c.DkgConfig.OldNodes
+	case NewRoster:
+		roster = c.DkgConfig.NewNodes
+	default:
+		return errors.New("dkg: unknown roster")
+	}
+	pub, ok := findIndex(roster, p.SenderIndex())
+	if !ok {
+		return errors.New("no nodes with this public key")
+	}
+	return c.Auth.Verify(pub, p.Hash(), p.Sig())
+}
+
 // Board is the interface between the dkg protocol and the external world. It
 // consists in pushing packets out to other nodes and receiving in packets from
 // the other nodes. A common board would use the network as the underlying
@@ -105,6 +168,11 @@ func NewProtocol(c *Config, b Board, phaser Phaser) (*Protocol, error) {
 	if c.DkgConfig.FastSync && c.Auth == nil {
 		return nil, errors.New("fast sync only allowed with authentication enabled")
 	}
+	// the nonce is what makes signed packets from this run non-replayable
+	// into another one, so it must be set whenever packets get signed
+	if c.Auth != nil && len(c.DkgConfig.Nonce) != NonceLength {
+		return nil, fmt.Errorf("dkg: nonce must be %d bytes when an authentication scheme is configured", NonceLength)
+	}
 	p := &Protocol{
 		board:    b,
 		phaser:   phaser,
@@ -261,46 +329,16 @@ func (p *Protocol) startFast() {
 	}
 }
 
-// VerifySignature takes the index of the sender of the packet, computes the
-// hash and verify if the signature is correct. VerifySignature expects a
-// pointer to  an AuthDealBundle, AuthResponseBundle, or AuthJustifBundle.
-// It returns nil if the Auth scheme in the config is nil.
+// VerifySignature checks that packet carries a valid signature from its
+// claimed sender. VerifySignature expects an AuthDealBundle,
+// AuthResponseBundle, or AuthJustifBundle. It returns nil if the Auth scheme
+// in the config is nil.
 func (p *Protocol) VerifySignature(packet interface{}) error {
-	if p.conf.Auth == nil {
-		return nil
-	}
-	var ok bool
-	var hash []byte
-	var pub kyber.Point
-	var sig []byte
-	switch auth := packet.(type) {
-	case AuthDealBundle:
-		hash = auth.Bundle.Hash()
-		pub, ok = findIndex(p.conf.DkgConfig.OldNodes, auth.Bundle.DealerIndex)
-		if !ok {
-			return errors.New("no nodes with this public key")
-		}
-		sig = auth.Signature
-	case AuthResponseBundle:
-		hash = auth.Bundle.Hash()
-		pub, ok = findIndex(p.conf.DkgConfig.NewNodes, auth.Bundle.ShareIndex)
-		if !ok {
-			return errors.New("no nodes with this public key")
-		}
-		sig = auth.Signature
-	case AuthJustifBundle:
-		hash = auth.Bundle.Hash()
-		pub, ok = findIndex(p.conf.DkgConfig.OldNodes, auth.Bundle.DealerIndex)
-		if !ok {
-			return errors.New("no nodes with this public key")
-		}
-		sig = auth.Signature
-	default:
+	pkt, ok := packet.(Packet)
+	if !ok {
 		return errors.New("unknown packet type")
 	}
-
-	err := p.conf.Auth.Verify(pub, hash, sig)
-	return err
+	return VerifyPacketSignature(p.conf, pkt)
 }
 
 type hashable interface {
@@ -365,16 +403,18 @@ func (p *Protocol) sendResponses(deals []*DealBundle) bool {
 
 func (p *Protocol) sendJustifications(resps []*ResponseBundle) bool {
 	res, just, err := p.dkg.ProcessResponses(resps)
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrEvicted) {
 		p.res <- OptionResult{
 			Error: err,
 		}
 		return false
 	}
 	if res != nil {
-		// we finished
+		// we finished, possibly with ErrEvicted if this node is no longer a
+		// share holder after a resharing
 		p.res <- OptionResult{
 			Result: res,
+			Error:  err,
 		}
 		return false
 	}
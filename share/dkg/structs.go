@@ -3,6 +3,7 @@ package dkg
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,6 +12,31 @@ import (
 	"github.com/drand/kyber/share"
 )
 
+// ErrEvicted is returned via OptionResult.Error when a resharing finishes
+// successfully for the new group as a whole, but the local node - which held
+// a share before the resharing - is not part of NewNodes. It is not returned
+// for any other reason. Callers should check for this with
+// errors.Is to distinguish "the protocol failed" from "I'm no longer a share
+// holder", since in the latter case Result.QUAL is still meaningful.
+var ErrEvicted = errors.New("dkg: local node evicted from the final QUAL")
+
+// BitSet tracks a set of node indexes, e.g. the dealers that a share holder
+// has complained about, or the nodes present in the final QUAL. It is used
+// instead of an ad-hoc slice because resharing needs precise per-dealer
+// complaint accounting to decide who gets disqualified.
+type BitSet map[uint32]bool
+
+// LengthComplaints returns the number of indexes flagged as true in the set.
+func (b BitSet) LengthComplaints() int {
+	var n int
+	for _, flagged := range b {
+		if flagged {
+			n++
+		}
+	}
+	return n
+}
+
 // Index is an alias to designate the index of a node. The index is used to
 // evaluate the share of a node, and is thereafter fixed. A node will use the
 // same index for generating a partial signature afterwards for example.
@@ -99,15 +125,26 @@ type DealBundle struct {
 	Deals       []Deal
 	// Public coefficients of the public polynomial used to create the shares
 	Public []kyber.Point
+	// Nonce is the per-session nonce of the DKG run this bundle belongs to,
+	// copied from DkgConfig.Nonce. It is mixed into Hash() so that a bundle
+	// signed for one run cannot be replayed into another.
+	Nonce []byte
 }
 
-// Hash hashes the index, public coefficients and deals
+// dealHashDomain domain-separates DealBundle hashes from every other bundle
+// type hashed by this package, so a signature over one can never be
+// reinterpreted as a signature over another.
+const dealHashDomain = "drand-dkg-deal-v1"
+
+// Hash hashes the domain tag, nonce, index, public coefficients and deals.
 func (d *DealBundle) Hash() []byte {
 	// first order the deals in a  stable order
 	sort.Slice(d.Deals, func(i, j int) bool {
 		return d.Deals[i].ShareIndex < d.Deals[j].ShareIndex
 	})
 	h := sha256.New()
+	h.Write([]byte(dealHashDomain))
+	h.Write(d.Nonce)
 	binary.Write(h, binary.BigEndian, d.DealerIndex)
 	for _, c := range d.Public {
 		cbuff, _ := c.MarshalBinary()
@@ -132,15 +169,25 @@ type ResponseBundle struct {
 	// Index of the share holder for which these reponses are for
 	ShareIndex uint32
 	Responses  []Response
+	// Nonce is the per-session nonce of the DKG run this bundle belongs to,
+	// copied from DkgConfig.Nonce. It is mixed into Hash() so that a bundle
+	// signed for one run cannot be replayed into another.
+	Nonce []byte
 }
 
-// Hash hashes the share index and responses
+// respHashDomain domain-separates ResponseBundle hashes from every other
+// bundle type hashed by this package.
+const respHashDomain = "drand-dkg-resp-v1"
+
+// Hash hashes the domain tag, nonce, share index and responses.
 func (r *ResponseBundle) Hash() []byte {
 	// first order the response slice in a canonical order
 	sort.Slice(r.Responses, func(i, j int) bool {
 		return r.Responses[i].DealerIndex < r.Responses[j].DealerIndex
 	})
 	h := sha256.New()
+	h.Write([]byte(respHashDomain))
+	h.Write(r.Nonce)
 	binary.Write(h, binary.BigEndian, r.ShareIndex)
 	for _, resp := range r.Responses {
 		binary.Write(h, binary.BigEndian, resp.DealerIndex)
@@ -166,6 +213,10 @@ func (b *ResponseBundle) String() string {
 type JustificationBundle struct {
 	DealerIndex    uint32
 	Justifications []Justification
+	// Nonce is the per-session nonce of the DKG run this bundle belongs to,
+	// copied from DkgConfig.Nonce. It is mixed into Hash() so that a bundle
+	// signed for one run cannot be replayed into another.
+	Nonce []byte
 }
 
 type Justification struct {
@@ -173,12 +224,19 @@ type Justification struct {
 	Share      kyber.Scalar
 }
 
+// justHashDomain domain-separates JustificationBundle hashes from every
+// other bundle type hashed by this package.
+const justHashDomain = "drand-dkg-just-v1"
+
+// Hash hashes the domain tag, nonce, dealer index and justifications.
 func (j *JustificationBundle) Hash() []byte {
 	// sort them in a canonical order
 	sort.Slice(j.Justifications, func(a, b int) bool {
 		return j.Justifications[a].ShareIndex < j.Justifications[b].ShareIndex
 	})
 	h := sha256.New()
+	h.Write([]byte(justHashDomain))
+	h.Write(j.Nonce)
 	binary.Write(h, binary.BigEndian, j.DealerIndex)
 	for _, just := range j.Justifications {
 		binary.Write(h, binary.BigEndian, just.ShareIndex)
@@ -193,12 +251,60 @@ type AuthDealBundle struct {
 	Signature []byte
 }
 
+// Hash implements Packet.
+func (a AuthDealBundle) Hash() []byte { return a.Bundle.Hash() }
+
+// Sig implements Packet.
+func (a AuthDealBundle) Sig() []byte { return a.Signature }
+
+// SenderIndex implements Packet. A deal is sent by a dealer, i.e. a member of
+// the old committee.
+func (a AuthDealBundle) SenderIndex() uint32 { return a.Bundle.DealerIndex }
+
+// SenderRoster implements Packet.
+func (a AuthDealBundle) SenderRoster() Roster { return OldRoster }
+
+// Nonce implements Packet.
+func (a AuthDealBundle) Nonce() []byte { return a.Bundle.Nonce }
+
 type AuthResponseBundle struct {
 	Bundle    *ResponseBundle
 	Signature []byte
 }
 
+// Hash implements Packet.
+func (a AuthResponseBundle) Hash() []byte { return a.Bundle.Hash() }
+
+// Sig implements Packet.
+func (a AuthResponseBundle) Sig() []byte { return a.Signature }
+
+// SenderIndex implements Packet. A response is sent by a share holder, i.e. a
+// member of the new committee.
+func (a AuthResponseBundle) SenderIndex() uint32 { return a.Bundle.ShareIndex }
+
+// SenderRoster implements Packet.
+func (a AuthResponseBundle) SenderRoster() Roster { return NewRoster }
+
+// Nonce implements Packet.
+func (a AuthResponseBundle) Nonce() []byte { return a.Bundle.Nonce }
+
 type AuthJustifBundle struct {
 	Bundle    *JustificationBundle
 	Signature []byte
-}
\ No newline at end of file
+}
+
+// Hash implements Packet.
+func (a AuthJustifBundle) Hash() []byte { return a.Bundle.Hash() }
+
+// Sig implements Packet.
+func (a AuthJustifBundle) Sig() []byte { return a.Signature }
+
+// SenderIndex implements Packet. A justification is sent by a dealer, i.e. a
+// member of the old committee.
+func (a AuthJustifBundle) SenderIndex() uint32 { return a.Bundle.DealerIndex }
+
+// SenderRoster implements Packet.
+func (a AuthJustifBundle) SenderRoster() Roster { return OldRoster }
+
+// Nonce implements Packet.
+func (a AuthJustifBundle) Nonce() []byte { return a.Bundle.Nonce }
@@ -0,0 +1,133 @@
+// Package tbls implements threshold BLS signing on top of a dkg.DistKeyShare,
+// so the output of a DKG run can directly feed a randomness beacon (or any
+// other collective-signing) loop without hand-rolling the pairing
+// arithmetic.
+//
+// Every signer holds one share of the group's BLS key produced by the
+// share/dkg package. It signs a message with PartialSign to get a partial
+// signature tagged with its index; once a threshold of partials have been
+// gathered and individually checked with VerifyPartial, Recover combines them
+// into the same signature the group's full secret would have produced, which
+// VerifyRecovered checks against the group's public key.
+package tbls
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/pairing/bls12381"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/drand/kyber/sign/bls"
+)
+
+// suite is the pairing suite every PartialSign / Recover call in this
+// package signs and verifies against. A deployment's DkgConfig.Suite must be
+// suite.G2() for the resulting DistKeyShare to be usable here, matching how
+// drand always runs its DKG and beacon signing over BLS12-381.
+var suite = bls12381.NewBLS12381Suite()
+
+// PartialSign returns share's partial BLS signature over msg, prefixed with
+// share's index so VerifyPartial, Recover and IndexOf can tell which signer
+// produced it.
+func PartialSign(share *dkg.DistKeyShare, msg []byte) ([]byte, error) {
+	sig, err := bls.Sign(suite, share.Share.V, msg)
+	if err != nil {
+		return nil, fmt.Errorf("tbls: signing partial: %w", err)
+	}
+	return encodeIndexed(dkg.Index(share.Share.I), sig), nil
+}
+
+// VerifyPartial checks that partial is a valid partial signature over msg
+// under the public polynomial commits.
+func VerifyPartial(commits []kyber.Point, msg, partial []byte) error {
+	idx, sig, err := decodeIndexed(partial)
+	if err != nil {
+		return err
+	}
+	public := share.NewPubPoly(suite.G2(), suite.G2().Point().Base(), commits)
+	pubShare := public.Eval(int(idx))
+	if err := bls.Verify(suite, pubShare.V, msg, sig); err != nil {
+		return fmt.Errorf("tbls: invalid partial signature from index %d: %w", idx, err)
+	}
+	return nil
+}
+
+// IndexOf returns the signer index embedded in a partial signature produced
+// by PartialSign.
+func IndexOf(partial []byte) (int, error) {
+	idx, _, err := decodeIndexed(partial)
+	if err != nil {
+		return 0, err
+	}
+	return int(idx), nil
+}
+
+// Recover combines t valid partial signatures over msg, verified against the
+// public polynomial commits, into the group's full BLS signature. It stops
+// as soon as t valid partials have been gathered, and rejects a partials
+// slice in which a partial it examines names the same signer index as an
+// earlier one; partials after the t-th valid one are not examined.
+func Recover(commits []kyber.Point, msg []byte, partials [][]byte, t, n int) ([]byte, error) {
+	public := share.NewPubPoly(suite.G2(), suite.G2().Point().Base(), commits)
+	seen := make(map[int]bool)
+	var sigShares []*share.PubShare
+	for _, partial := range partials {
+		if len(sigShares) >= t {
+			break
+		}
+		idx, sig, err := decodeIndexed(partial)
+		if err != nil {
+			continue
+		}
+		if seen[int(idx)] {
+			return nil, fmt.Errorf("tbls: duplicate partial signature for index %d", idx)
+		}
+		seen[int(idx)] = true
+		pubShare := public.Eval(int(idx))
+		if err := bls.Verify(suite, pubShare.V, msg, sig); err != nil {
+			continue
+		}
+		point := suite.G1().Point()
+		if err := point.UnmarshalBinary(sig); err != nil {
+			continue
+		}
+		sigShares = append(sigShares, &share.PubShare{I: int(idx), V: point})
+	}
+	if len(sigShares) < t {
+		return nil, fmt.Errorf("tbls: only %d/%d valid partial signatures, need %d", len(sigShares), n, t)
+	}
+	recovered, err := share.RecoverCommit(suite.G1(), sigShares, t, n)
+	if err != nil {
+		return nil, fmt.Errorf("tbls: recovering signature: %w", err)
+	}
+	return recovered.MarshalBinary()
+}
+
+// VerifyRecovered checks that sig is a valid BLS signature over msg under the
+// group's public key pub.
+func VerifyRecovered(pub kyber.Point, msg, sig []byte) error {
+	if err := bls.Verify(suite, pub, msg, sig); err != nil {
+		return fmt.Errorf("tbls: invalid recovered signature: %w", err)
+	}
+	return nil
+}
+
+// encodeIndexed prefixes sig with i as a 4-byte big-endian index.
+func encodeIndexed(i dkg.Index, sig []byte) []byte {
+	out := make([]byte, 4+len(sig))
+	binary.BigEndian.PutUint32(out, uint32(i))
+	copy(out[4:], sig)
+	return out
+}
+
+// decodeIndexed splits a buffer produced by encodeIndexed back into its
+// index and signature.
+func decodeIndexed(buf []byte) (dkg.Index, []byte, error) {
+	if len(buf) < 4 {
+		return 0, nil, errors.New("tbls: partial signature too short")
+	}
+	return dkg.Index(binary.BigEndian.Uint32(buf[:4])), buf[4:], nil
+}
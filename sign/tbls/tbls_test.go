@@ -0,0 +1,94 @@
+package tbls
+
+import (
+	"testing"
+
+	"github.com/drand/kyber/pairing/bls12381"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/drand/kyber/util/random"
+)
+
+const (
+	testN = 5
+	testT = 4
+)
+
+// testGroup builds a fake DKG output: a threshold sharing of a single
+// secret, split testN ways, so PartialSign/Recover have something to sign
+// and combine without needing to run a real DKG.
+func testGroup(t *testing.T) (pubPoly *share.PubPoly, shares []*dkg.DistKeyShare) {
+	t.Helper()
+	secret := suite.G2().Scalar().Pick(random.New())
+	priPoly := share.NewPriPoly(suite.G2(), testT, secret, random.New())
+	pubPoly = priPoly.Commit(suite.G2().Point().Base())
+	for _, s := range priPoly.Shares(testN) {
+		shares = append(shares, &dkg.DistKeyShare{Commits: pubPoly.Commits(), Share: s})
+	}
+	return pubPoly, shares
+}
+
+func TestPartialSignVerifyRecover(t *testing.T) {
+	pubPoly, shares := testGroup(t)
+	msg := []byte("hello tbls")
+
+	var partials [][]byte
+	for _, dks := range shares {
+		partial, err := PartialSign(dks, msg)
+		if err != nil {
+			t.Fatalf("PartialSign: %v", err)
+		}
+		if err := VerifyPartial(pubPoly.Commits(), msg, partial); err != nil {
+			t.Fatalf("VerifyPartial: %v", err)
+		}
+		idx, err := IndexOf(partial)
+		if err != nil {
+			t.Fatalf("IndexOf: %v", err)
+		}
+		if idx != dks.Share.I {
+			t.Fatalf("IndexOf = %d, want %d", idx, dks.Share.I)
+		}
+		partials = append(partials, partial)
+	}
+
+	sig, err := Recover(pubPoly.Commits(), msg, partials, testT, testN)
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if err := VerifyRecovered(pubPoly.Commits()[0], msg, sig); err != nil {
+		t.Fatalf("VerifyRecovered: %v", err)
+	}
+}
+
+func TestRecoverRejectsDuplicateIndex(t *testing.T) {
+	pubPoly, shares := testGroup(t)
+	msg := []byte("hello tbls")
+
+	partial, err := PartialSign(shares[0], msg)
+	if err != nil {
+		t.Fatalf("PartialSign: %v", err)
+	}
+	partials := [][]byte{partial, partial}
+
+	if _, err := Recover(pubPoly.Commits(), msg, partials, testT, testN); err == nil {
+		t.Fatal("Recover should reject a partials slice with a duplicate index")
+	}
+}
+
+func TestRecoverInsufficientPartials(t *testing.T) {
+	pubPoly, shares := testGroup(t)
+	msg := []byte("hello tbls")
+
+	var partials [][]byte
+	for _, dks := range shares[:testT-1] {
+		partial, err := PartialSign(dks, msg)
+		if err != nil {
+			t.Fatalf("PartialSign: %v", err)
+		}
+		partials = append(partials, partial)
+	}
+
+	if _, err := Recover(pubPoly.Commits(), msg, partials, testT, testN); err == nil {
+		t.Fatal("Recover should fail with fewer than t valid partials")
+	}
+}